@@ -0,0 +1,141 @@
+package mage
+
+// #cgo pkg-config: MagickWand MagickCore
+// #include <stdlib.h>
+// #include <wand/MagickWand.h>
+import "C"
+
+import (
+  "unsafe"
+)
+
+// Public: Drawing wraps a DrawingWand, holding the font, size and fill
+// color used to annotate a Mage's image.
+type Drawing struct {
+  wand *C.DrawingWand
+}
+
+// Public: create a new drawing, associate with a new drawing wand
+//
+// Examples:
+//  d := NewDrawingWand()
+//  defer d.DestroyDrawingWand()
+func NewDrawingWand() *Drawing {
+  return &Drawing{wand: C.NewDrawingWand()}
+}
+
+// Public: destroy the drawing wand, releasing the memory held by it
+//
+// Examples:
+//  d.DestroyDrawingWand()
+func (d *Drawing) DestroyDrawingWand() {
+  defer C.DestroyDrawingWand(d.wand)
+}
+
+// Private: build a PixelWand from a color string, e.g. "white" or "#ff0000"
+func pixelWand(color string) (*C.PixelWand, error) {
+  ccolor := C.CString(color)
+  defer C.free(unsafe.Pointer(ccolor))
+  pixel := C.NewPixelWand()
+  if !mBoolean(C.PixelSetColor(pixel, ccolor)) {
+    C.DestroyPixelWand(pixel)
+    return nil, &MagickError{Description: "failed to parse color " + color}
+  }
+  return pixel, nil
+}
+
+// Public: set the font used to annotate with, e.g. "Helvetica" or a path to
+// a font file
+//
+// Examples:
+//  d.SetFont("Helvetica")
+func (d *Drawing) SetFont(font string) error {
+  cfont := C.CString(font)
+  defer C.free(unsafe.Pointer(cfont))
+  if !mBoolean(C.DrawSetFont(d.wand, cfont)) {
+    return &MagickError{Description: "failed to set font " + font}
+  }
+  return nil
+}
+
+// Public: set the font size used to annotate with
+//
+// Examples:
+//  d.SetFontSize(24)
+func (d *Drawing) SetFontSize(size float64) {
+  C.DrawSetFontSize(d.wand, C.double(size))
+}
+
+// Public: set the color text is filled with when annotating
+//
+// Examples:
+//  d.SetFillColor("white")
+func (d *Drawing) SetFillColor(color string) error {
+  pixel, err := pixelWand(color)
+  if err != nil {
+    return err
+  }
+  defer C.DestroyPixelWand(pixel)
+  C.DrawSetFillColor(d.wand, pixel)
+  return nil
+}
+
+// Public: FontMetrics describes the measured dimensions of a string of text
+// rendered with a given Drawing's font and size.
+type FontMetrics struct {
+  Width float64
+  Height float64
+  Ascent float64
+  Descent float64
+}
+
+// Public: annotate the image with text at x, y using d's font, size and
+// fill color
+//
+// Examples:
+//  d := NewDrawingWand()
+//  d.SetFont("Helvetica")
+//  d.SetFontSize(24)
+//  d.SetFillColor("white")
+//  im.Annotate(d, 10, 30, "hello")
+func (m *Mage) Annotate(d *Drawing, x, y float64, text string) error {
+  ctext := C.CString(text)
+  defer C.free(unsafe.Pointer(ctext))
+  if !mBoolean(C.MagickAnnotateImage(m.wand, d.wand, C.double(x), C.double(y), C.double(0), ctext)) {
+    return mError(m.wand)
+  }
+  return nil
+}
+
+// Public: commit any primitives queued on d (e.g. via the DrawingWand's own
+// Draw* calls) onto the image
+//
+// Examples:
+//  im.DrawImage(d)
+func (m *Mage) DrawImage(d *Drawing) error {
+  if !mBoolean(C.MagickDrawImage(m.wand, d.wand)) {
+    return mError(m.wand)
+  }
+  return nil
+}
+
+// Public: measure the width, height, ascent and descent text would occupy
+// if annotated with d's current font and size - useful for re-measuring the
+// width of a space once the font or size changes, e.g. when laying out a
+// watermark or caption.
+//
+// Examples:
+//  metrics := im.QueryFontMetrics(d, "hello")
+func (m *Mage) QueryFontMetrics(d *Drawing, text string) FontMetrics {
+  ctext := C.CString(text)
+  defer C.free(unsafe.Pointer(ctext))
+  metrics := C.MagickQueryFontMetrics(m.wand, d.wand, ctext)
+  defer C.MagickRelinquishMemory(unsafe.Pointer(metrics))
+  values := (*[13]C.double)(unsafe.Pointer(metrics))
+  return FontMetrics{
+    Width: float64(values[4]),
+    Height: float64(values[5]),
+    Ascent: float64(values[2]),
+    Descent: float64(values[3]),
+  }
+}
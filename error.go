@@ -0,0 +1,90 @@
+package mage
+
+// #cgo pkg-config: MagickWand MagickCore
+// #include <stdlib.h>
+// #include <wand/MagickWand.h>
+import "C"
+
+import (
+  "unsafe"
+)
+
+// Public: MagickError wraps an ImageMagick exception captured via
+// MagickGetException/MagickGetExceptionType right after an operation fails.
+type MagickError struct {
+  Severity int
+  Description string
+}
+
+func (e *MagickError) Error() string {
+  return e.Description
+}
+
+// Private: capture the wand's current exception as a MagickError, then
+// clear it so it doesn't leak into the next operation.
+//
+// Examples:
+//  if !mBoolean(C.MagickStripImage(m.wand)) {
+//    return mError(m.wand)
+//  }
+func mError(wand *C.MagickWand) error {
+  var severity C.ExceptionType
+  description := C.MagickGetException(wand, &severity)
+  defer C.MagickRelinquishMemory(unsafe.Pointer(description))
+  defer C.MagickClearException(wand)
+  if severity == C.UndefinedException {
+    return nil
+  }
+  return &MagickError{Severity: int(severity), Description: C.GoString(description)}
+}
+
+// Public: kinds of resource limits that can be bounded via SetResourceLimit,
+// mirroring MagickWand's ResourceType constants.
+type ResourceType int
+
+const (
+  MemoryResource ResourceType = iota
+  MapResource
+  DiskResource
+  AreaResource
+  FileResource
+  ThreadResource
+  WidthResource
+  HeightResource
+)
+
+// Private: translate a ResourceType into its C.ResourceType constant
+func (r ResourceType) cType() C.ResourceType {
+  switch r {
+  case MapResource:
+    return C.MapResource
+  case DiskResource:
+    return C.DiskResource
+  case AreaResource:
+    return C.AreaResource
+  case FileResource:
+    return C.FileResource
+  case ThreadResource:
+    return C.ThreadResource
+  case WidthResource:
+    return C.WidthResource
+  case HeightResource:
+    return C.HeightResource
+  default:
+    return C.MemoryResource
+  }
+}
+
+// Public: bound how much of a given resource (memory, disk, threads, ...)
+// ImageMagick may use for subsequent operations - a common hardening step
+// for image services that decode untrusted input.
+//
+// Examples:
+//  SetResourceLimit(MemoryResource, 256 * 1024 * 1024)
+//  SetResourceLimit(ThreadResource, 1)
+func SetResourceLimit(kind ResourceType, limit uint64) error {
+  if !mBoolean(C.MagickSetResourceLimit(kind.cType(), C.MagickSizeType(limit))) {
+    return &MagickError{Description: "failed to set resource limit"}
+  }
+  return nil
+}
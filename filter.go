@@ -0,0 +1,137 @@
+package mage
+
+// #cgo pkg-config: MagickWand MagickCore
+// #include <wand/MagickWand.h>
+import "C"
+
+// Public: resampling filters available to SetResampleFilter, mirroring
+// MagickWand's FilterTypes constants.
+type FilterType int
+
+const (
+  Point FilterType = iota
+  Box
+  Triangle
+  Hermite
+  Hanning
+  Hamming
+  Blackman
+  Gaussian
+  Quadratic
+  Cubic
+  Catrom
+  Mitchell
+  Lanczos
+  Bessel
+  Sinc
+  Lanczos2
+  LanczosSharp
+)
+
+// Private: translate a FilterType into its C.FilterTypes constant, or an
+// error if filter isn't one of the named constants above
+func (f FilterType) cType() (C.FilterTypes, error) {
+  switch f {
+  case Point:
+    return C.PointFilter, nil
+  case Box:
+    return C.BoxFilter, nil
+  case Triangle:
+    return C.TriangleFilter, nil
+  case Hermite:
+    return C.HermiteFilter, nil
+  case Hanning:
+    return C.HanningFilter, nil
+  case Hamming:
+    return C.HammingFilter, nil
+  case Blackman:
+    return C.BlackmanFilter, nil
+  case Gaussian:
+    return C.GaussianFilter, nil
+  case Quadratic:
+    return C.QuadraticFilter, nil
+  case Cubic:
+    return C.CubicFilter, nil
+  case Catrom:
+    return C.CatromFilter, nil
+  case Mitchell:
+    return C.MitchellFilter, nil
+  case Lanczos:
+    return C.LanczosFilter, nil
+  case Bessel:
+    return C.BesselFilter, nil
+  case Sinc:
+    return C.SincFilter, nil
+  case Lanczos2:
+    return C.Lanczos2Filter, nil
+  case LanczosSharp:
+    return C.LanczosSharpFilter, nil
+  default:
+    return C.LanczosFilter, &MagickError{Description: "unknown resample filter"}
+  }
+}
+
+// Private: the filter and blur factor Resize/Thumbnail should resample
+// with, as set by SetResampleFilter. Defaults to Lanczos with a neutral
+// blur of 1.0, matching the filter resize always used before
+// SetResampleFilter existed.
+//
+// Examples:
+//  m.resampleFilter()
+func (m *Mage) resampleFilter() (C.FilterTypes, C.double) {
+  if !m.filterSet {
+    filter, _ := Lanczos.cType()
+    return filter, C.double(1.0)
+  }
+  // m.filter was already validated by SetResampleFilter, so the error
+  // return here can never fire.
+  filter, _ := m.filter.cType()
+  return filter, C.double(m.blur)
+}
+
+// Public: set the resample filter and blur factor used by Resize and
+// Thumbnail when scaling images. blur of 1.0 is neutral; below 1.0
+// sharpens, above 1.0 softens.
+//
+// Examples:
+//  im.SetResampleFilter(Mitchell, 1.0)
+func (m *Mage) SetResampleFilter(filter FilterType, blur float64) error {
+  if _, err := filter.cType(); err != nil {
+    return err
+  }
+  m.filter = filter
+  m.blur = blur
+  m.filterSet = true
+  return nil
+}
+
+// Public: select the MagickThumbnailImage fast path for Resize and
+// Thumbnail, instead of strip+resize with the configured resample filter.
+// MagickThumbnailImage strips profiles and uses a cheaper filter
+// automatically, which is about 2-3x faster for bulk JPEG thumbnailing at
+// some quality cost.
+//
+// Examples:
+//  im.SetFast(true)
+func (m *Mage) SetFast(fast bool) {
+  m.fast = fast
+}
+
+// Private: scale the current image to scaledWidth x scaledHeight, using
+// the fast MagickThumbnailImage path when SetFast(true) was called, or
+// strip+resize with the configured resample filter otherwise.
+//
+// Examples:
+//  m.scaleImage(100, 100)
+func (m *Mage) scaleImage(scaledWidth, scaledHeight int) error {
+  if m.fast {
+    if !mBoolean(C.MagickThumbnailImage(m.wand, C.ulong(scaledWidth), C.ulong(scaledHeight))) {
+      return mError(m.wand)
+    }
+    return nil
+  }
+  if err := m.strip(); err != nil {
+    return err
+  }
+  return m.resize(scaledWidth, scaledHeight)
+}
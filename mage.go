@@ -12,6 +12,11 @@ import (
 
 type Mage struct {
   wand *C.MagickWand
+  background string
+  filter FilterType
+  filterSet bool
+  blur float64
+  fast bool
 }
 
 // Private: Convert C.MagickBooleanType to boolean type
@@ -42,26 +47,29 @@ func round(x float64) int {
 // - format: format of the new image
 // - width: width of the new image
 // - height: height of the new image
+// - color: background color of the new image, e.g. "none" or "white"
 //
 // Examples
-//  blankWand("jpg", 100, 100)
+//  blankWand("jpg", 100, 100, "none")
 //
-// Return *C.MagickWand
-func blankWand(format string, width, height int) *C.MagickWand {
+// Return *C.MagickWand, or an error if color isn't a color PixelSetColor
+// understands
+func blankWand(format string, width, height int, color string) (*C.MagickWand, error) {
   wand := C.NewMagickWand()
   cformat := C.CString(format)
-  noneBackground := C.CString("none")
   defer C.free(unsafe.Pointer(cformat))
-  defer C.free(unsafe.Pointer(noneBackground))
 
-  C.MagickSetFormat(wand, C.CString(format))
-  pixel := C.NewPixelWand()
+  C.MagickSetFormat(wand, cformat)
+  pixel, err := pixelWand(color)
+  if err != nil {
+    C.DestroyMagickWand(wand)
+    return nil, err
+  }
   defer C.DestroyPixelWand(pixel)
 
-  C.PixelSetColor(pixel, noneBackground);
   C.MagickSetSize(wand, C.ulong(width), C.ulong(height));
   C.MagickNewImage(wand, C.ulong(width), C.ulong(height), pixel)
-  return wand
+  return wand, nil
 }
 
 // Private: scale wand's image respected the initial ratio. At least one of the
@@ -103,17 +111,21 @@ func (m *Mage) scale(width , height int) (scaledWidth, scaledHeight int) {
 //  newWand := blankWand("jpg", width, height)
 //  done := m.compositeCenter(newWand, 10, 10)
 //
-// Return boolean result of the composition
-func (m *Mage) compositeCenter(newWand *C.MagickWand, x, y int) bool{
+// Return error, if any, reported for the composition
+func (m *Mage) compositeCenter(newWand *C.MagickWand, x, y int) error {
   success := C.MagickCompositeImage(
     newWand,
     m.wand,
     C.OverCompositeOp,
     C.long(x),
     C.long(y))
+  var err error
+  if !mBoolean(success) {
+    err = mError(newWand)
+  }
   C.DestroyMagickWand(m.wand)
   m.wand = newWand
-  return mBoolean(success)
+  return err
 }
 
 // Private: resize the current image to new width and height
@@ -124,21 +136,98 @@ func (m *Mage) compositeCenter(newWand *C.MagickWand, x, y int) bool{
 //
 // Examples:
 //  m.resize(100, 100)
-func (m *Mage) resize(width, height int) bool {
-  return mBoolean(C.MagickResizeImage(
+func (m *Mage) resize(width, height int) error {
+  filter, blur := m.resampleFilter()
+  if !mBoolean(C.MagickResizeImage(
     m.wand,
     C.ulong(width),
     C.ulong(height),
-    C.LanczosFilter,
-    C.double(1.0)))
+    filter,
+    blur)) {
+    return mError(m.wand)
+  }
+  return nil
 }
 
 // Private: strip all comments and profiles from an image
 //
 // Examples:
 //  m.strip()
-func (m *Mage) strip() bool {
-  return mBoolean(C.MagickStripImage(m.wand))
+func (m *Mage) strip() error {
+  if !mBoolean(C.MagickStripImage(m.wand)) {
+    return mError(m.wand)
+  }
+  return nil
+}
+
+// Private: get the current format of the image, e.g. "JPEG", "PNG", "GIF"
+//
+// Examples:
+//  m.format()
+func (m *Mage) format() string {
+  cformat := C.MagickGetImageFormat(m.wand)
+  defer C.MagickRelinquishMemory(unsafe.Pointer(cformat))
+  return C.GoString(cformat)
+}
+
+// Private: the background color new canvases should be composited onto, as
+// set by SetBackgroundColor. Defaults to "none" (transparent).
+//
+// Examples:
+//  m.backgroundColor()
+func (m *Mage) backgroundColor() string {
+  if m.background == "" {
+    return "none"
+  }
+  return m.background
+}
+
+// Public: set the background color of the canvas that Resize and
+// Thumbnail's FitInside and PadCenter modes composite the scaled image
+// onto. It shows only where the scaled image leaves the canvas uncovered,
+// which happens in FitInside; Resize and PadCenter scale the image to cover
+// the whole canvas. Accepts any color string ImageMagick's PixelSetColor
+// understands, such as "none" or "white".
+//
+// Examples:
+//  im.SetBackgroundColor("white")
+func (m *Mage) SetBackgroundColor(color string) {
+  m.background = color
+}
+
+// Private: like scale, but computes a "cover" or "contain" ratio - used by
+// Thumbnail's FillCrop and FitInside modes respectively. FitInside never
+// upscales a source image smaller than the target, since it has no crop
+// step to absorb the extra canvas; FillCrop must be allowed to upscale,
+// since it needs the scaled image to fully cover width/height before it
+// crops away the excess.
+//
+// Params:
+// - width: new width
+// - height: new height
+// - cover: true to scale to the min ratio that fully covers width/height,
+//   false to scale to the max ratio that fits entirely within it
+//
+// Returns a pair of scaled width and height of the new image
+func (m *Mage) thumbnailScale(width, height int, cover bool) (scaledWidth, scaledHeight int) {
+  imageWidth := m.Width()
+  imageHeight := m.Height()
+  var ratio float64
+  if cover {
+    ratio = math.Max(
+      float64(width)/float64(imageWidth),
+      float64(height)/float64(imageHeight))
+  } else {
+    ratio = math.Min(
+      float64(width)/float64(imageWidth),
+      float64(height)/float64(imageHeight))
+    if ratio > 1.0 {
+      ratio = 1.0
+    }
+  }
+  scaledWidth = round(ratio * (float64(imageWidth) + 0.5))
+  scaledHeight = round(ratio * (float64(imageHeight) + 0.5))
+  return scaledWidth, scaledHeight
 }
 
 // Public: read a blob data into the current wand
@@ -146,12 +235,15 @@ func (m *Mage) strip() bool {
 // Examples:
 //  im = NewMage()
 //  original, err := ioutil.ReadFile("test.jpg")
-//  success := im.ReadBlob(original)
-func (m *Mage) ReadBlob(blob []byte) bool {
-  return mBoolean(C.MagickReadImageBlob(
+//  err = im.ReadBlob(original)
+func (m *Mage) ReadBlob(blob []byte) error {
+  if !mBoolean(C.MagickReadImageBlob(
     m.wand,
     unsafe.Pointer(&blob[0]),
-    C.ulong(len(blob))))
+    C.ulong(len(blob)))) {
+    return mError(m.wand)
+  }
+  return nil
 }
 
 // Public: export the current image into a blob. Also destroy the current wand
@@ -184,16 +276,84 @@ func (m *Mage) ExportBlob() []byte {
 // Examples:
 //  im = NewMage()
 //  original, err := ioutil.ReadFile("test.jpg")
-//  success := im.ReadBlob(original)
-//  success = im.Resize(100, 100)
-func (m *Mage) Resize(width, height int) bool {
-  var done bool;
+//  err = im.ReadBlob(original)
+//  err = im.Resize(100, 100)
+func (m *Mage) Resize(width, height int) error {
   scaledWidth, scaledHeight := m.scale(width, height)
-  done = m.strip()
-  done = m.resize(scaledWidth, scaledHeight)
-  newWand := blankWand("jpg", width, height)
-  done = m.compositeCenter(newWand, int((width - scaledWidth) / 2), int((height - scaledHeight) / 2))
-  return done
+  if err := m.scaleImage(scaledWidth, scaledHeight); err != nil {
+    return err
+  }
+  newWand, err := blankWand(m.format(), width, height, m.backgroundColor())
+  if err != nil {
+    return err
+  }
+  return m.compositeCenter(newWand, int((width - scaledWidth) / 2), int((height - scaledHeight) / 2))
+}
+
+// Public: modes available to Thumbnail, controlling how the source image is
+// fit onto the new width x height canvas.
+type ThumbnailMode int
+
+const (
+  // FitInside scales the image to fit entirely within the new canvas
+  // without cropping, letterboxing any leftover space with the background
+  // color.
+  FitInside ThumbnailMode = iota
+  // FillCrop scales the image to cover the new canvas completely, then
+  // crops whatever spills over the edges.
+  FillCrop
+  // PadCenter is Resize's scale-to-max-ratio-then-center behavior.
+  PadCenter
+)
+
+// Public: resize the image into a width x height thumbnail, using mode to
+// decide how the source is fit onto the new canvas. In FitInside mode,
+// source images smaller than the target are centered on a background of
+// color (see SetBackgroundColor) rather than upscaled; FillCrop and
+// PadCenter upscale them until they cover the canvas.
+//
+// Examples:
+//  im.Thumbnail(100, 100, FitInside)
+//  im.Thumbnail(100, 100, FillCrop)
+//  im.Thumbnail(100, 100, PadCenter)
+func (m *Mage) Thumbnail(width, height int, mode ThumbnailMode) error {
+  switch mode {
+  case FillCrop:
+    return m.thumbnailFillCrop(width, height)
+  case PadCenter:
+    return m.Resize(width, height)
+  default:
+    return m.thumbnailFitInside(width, height)
+  }
+}
+
+// Private: fit the image entirely within width x height, without cropping,
+// centering the result on a background-colored canvas.
+func (m *Mage) thumbnailFitInside(width, height int) error {
+  scaledWidth, scaledHeight := m.thumbnailScale(width, height, false)
+  if err := m.scaleImage(scaledWidth, scaledHeight); err != nil {
+    return err
+  }
+  newWand, err := blankWand(m.format(), width, height, m.backgroundColor())
+  if err != nil {
+    return err
+  }
+  return m.compositeCenter(newWand, (width-scaledWidth)/2, (height-scaledHeight)/2)
+}
+
+// Private: scale the image to cover width x height, then crop away
+// whatever spills over the edges once centered.
+func (m *Mage) thumbnailFillCrop(width, height int) error {
+  scaledWidth, scaledHeight := m.thumbnailScale(width, height, true)
+  if err := m.scaleImage(scaledWidth, scaledHeight); err != nil {
+    return err
+  }
+  x := C.long((scaledWidth - width) / 2)
+  y := C.long((scaledHeight - height) / 2)
+  if !mBoolean(C.MagickCropImage(m.wand, C.ulong(width), C.ulong(height), x, y)) {
+    return mError(m.wand)
+  }
+  return nil
 }
 
 // Public: get current width of the image
@@ -35,8 +35,8 @@ func readImage(c *C, filename string) *Mage {
   c.Check(err, IsNil, Commentf("Fail to read image file"))
 
   im = NewMage()
-  success := im.ReadBlob(original)
-  c.Check(success, Equals, true, Commentf("Fail to read blob"))
+  err = im.ReadBlob(original)
+  c.Check(err, IsNil, Commentf("Fail to read blob"))
   return im
 }
 
@@ -76,3 +76,113 @@ func (s *S) TestResize(c *C) {
   exported, _, _ := image.Decode(out)
   assertSize(c, exported, expectedWidth, expectedHeight)
 }
+
+func (s *S) TestThumbnailFitInside(c *C) {
+  filename := "images/out/test_thumbnail_fit_inside.jpg"
+  im := readImage(c, "images/in/test.jpg")
+  expectedWidth := 100
+  expectedHeight := 100
+  err := im.Thumbnail(expectedWidth, expectedHeight, FitInside)
+  c.Check(err, IsNil)
+  writeFile(im, filename)
+  out, _ := os.Open(filename)
+  defer out.Close()
+  exported, _, _ := image.Decode(out)
+  assertSize(c, exported, expectedWidth, expectedHeight)
+}
+
+func (s *S) TestAnnotate(c *C) {
+  filename := "images/out/test_annotate.jpg"
+  im := readImage(c, "images/in/test.jpg")
+  d := NewDrawingWand()
+  defer d.DestroyDrawingWand()
+  d.SetFontSize(24)
+  err := d.SetFillColor("white")
+  c.Check(err, IsNil)
+  err = im.Annotate(d, 10, 30, "hello")
+  c.Check(err, IsNil)
+  writeFile(im, filename)
+  out, _ := os.Open(filename)
+  defer out.Close()
+  _, _, err = image.Decode(out)
+  c.Check(err, IsNil)
+}
+
+func (s *S) TestQueryFontMetrics(c *C) {
+  im := readImage(c, "images/in/test.jpg")
+  d := NewDrawingWand()
+  defer d.DestroyDrawingWand()
+  d.SetFontSize(24)
+  metrics := im.QueryFontMetrics(d, "hello")
+  c.Assert(metrics.Width > 0, Equals, true)
+}
+
+func (s *S) TestResizeWithResampleFilter(c *C) {
+  filename := "images/out/test_resize_mitchell.jpg"
+  im := readImage(c, "images/in/test.jpg")
+  err := im.SetResampleFilter(Mitchell, 1.0)
+  c.Check(err, IsNil)
+  expectedWidth := 100
+  expectedHeight := 100
+  err = im.Resize(expectedWidth, expectedHeight)
+  c.Check(err, IsNil)
+  writeFile(im, filename)
+  out, _ := os.Open(filename)
+  defer out.Close()
+  exported, _, _ := image.Decode(out)
+  assertSize(c, exported, expectedWidth, expectedHeight)
+}
+
+func (s *S) TestSetResampleFilterInvalid(c *C) {
+  im := readImage(c, "images/in/test.jpg")
+  err := im.SetResampleFilter(FilterType(999), 1.0)
+  c.Assert(err, NotNil)
+}
+
+func (s *S) TestThumbnailFast(c *C) {
+  filename := "images/out/test_thumbnail_fast.jpg"
+  im := readImage(c, "images/in/test.jpg")
+  im.SetFast(true)
+  expectedWidth := 100
+  expectedHeight := 100
+  err := im.Thumbnail(expectedWidth, expectedHeight, FitInside)
+  c.Check(err, IsNil)
+  writeFile(im, filename)
+  out, _ := os.Open(filename)
+  defer out.Close()
+  exported, _, _ := image.Decode(out)
+  assertSize(c, exported, expectedWidth, expectedHeight)
+}
+
+func (s *S) TestSetResourceLimit(c *C) {
+  err := SetResourceLimit(MemoryResource, 256 * 1024 * 1024)
+  c.Assert(err, IsNil)
+}
+
+func (s *S) TestThumbnailFillCrop(c *C) {
+  filename := "images/out/test_thumbnail_fill_crop.jpg"
+  im := readImage(c, "images/in/test.jpg")
+  expectedWidth := 100
+  expectedHeight := 100
+  err := im.Thumbnail(expectedWidth, expectedHeight, FillCrop)
+  c.Check(err, IsNil)
+  writeFile(im, filename)
+  out, _ := os.Open(filename)
+  defer out.Close()
+  exported, _, _ := image.Decode(out)
+  assertSize(c, exported, expectedWidth, expectedHeight)
+}
+
+func (s *S) TestThumbnailFillCropUpscale(c *C) {
+  filename := "images/out/test_thumbnail_fill_crop_upscale.jpg"
+  im := readImage(c, "images/in/test.jpg")
+  expectedWidth := 800
+  expectedHeight := 800
+  err := im.Thumbnail(expectedWidth, expectedHeight, FillCrop)
+  c.Check(err, IsNil)
+  writeFile(im, filename)
+  out, _ := os.Open(filename)
+  defer out.Close()
+  exported, _, _ := image.Decode(out)
+  assertSize(c, exported, expectedWidth, expectedHeight)
+}
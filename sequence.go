@@ -0,0 +1,125 @@
+package mage
+
+// #cgo pkg-config: MagickWand MagickCore
+// #include <stdlib.h>
+// #include <wand/MagickWand.h>
+import "C"
+
+import (
+  "unsafe"
+)
+
+// Public: MageSequence wraps a MagickWand that may hold more than one frame,
+// e.g. an animated GIF, APNG, or animated WebP. Unlike Mage.Resize, which
+// composites onto a fresh single-frame wand, operations on a MageSequence
+// are applied to each frame in place so delay and dispose settings survive.
+type MageSequence struct {
+  wand *C.MagickWand
+}
+
+// Public: create a new mage sequence, associate with a new magick wand
+//
+// Examples:
+//  InitWandEnv()
+//  seq := NewMageSequence()
+//  ...
+//  TermWandEnv()
+func NewMageSequence() *MageSequence {
+  seq := &MageSequence{}
+  seq.wand = C.NewMagickWand()
+  return seq
+}
+
+// Public: read a blob data into the current sequence, preserving all frames
+//
+// Examples:
+//  seq = NewMageSequence()
+//  original, err := ioutil.ReadFile("test.gif")
+//  err = seq.ReadBlob(original)
+func (s *MageSequence) ReadBlob(blob []byte) error {
+  if !mBoolean(C.MagickReadImageBlob(
+    s.wand,
+    unsafe.Pointer(&blob[0]),
+    C.ulong(len(blob)))) {
+    return mError(s.wand)
+  }
+  return nil
+}
+
+// Public: number of frames held by the current sequence
+//
+// Examples:
+//  seq.FrameCount()
+func (s *MageSequence) FrameCount() int {
+  return int(C.MagickGetNumberImages(s.wand))
+}
+
+// Public: run fn against every frame in the sequence, in order. fn receives
+// a Mage wrapping whichever frame the iterator currently points at, so
+// operations on it (resize, strip, ...) apply to that frame only. Stops and
+// returns the first error fn returns.
+//
+// Examples:
+//  err := seq.ForEachFrame(func(frame *Mage) error {
+//    frame.strip()
+//    return nil
+//  })
+func (s *MageSequence) ForEachFrame(fn func(*Mage) error) error {
+  C.MagickResetIterator(s.wand)
+  frame := &Mage{wand: s.wand}
+  for mBoolean(C.MagickNextImage(s.wand)) {
+    if err := fn(frame); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// Public: resize every frame to width x height, scaling each frame to the
+// max ratio and centering it on the new canvas with MagickExtentImage -
+// the same scale-then-center shape as Mage.Resize, but done per-frame so
+// the sequence's other frames aren't destroyed in the process.
+//
+// Examples:
+//  seq.ResizeAll(100, 100)
+func (s *MageSequence) ResizeAll(width, height int) error {
+  return s.ForEachFrame(func(frame *Mage) error {
+    scaledWidth, scaledHeight := frame.scale(width, height)
+    if err := frame.strip(); err != nil {
+      return err
+    }
+    if err := frame.resize(scaledWidth, scaledHeight); err != nil {
+      return err
+    }
+    x := C.long((scaledWidth - width) / 2)
+    y := C.long((scaledHeight - height) / 2)
+    if !mBoolean(C.MagickExtentImage(s.wand, C.ulong(width), C.ulong(height), x, y)) {
+      return mError(s.wand)
+    }
+    return nil
+  })
+}
+
+// Public: export every frame of the current sequence into a blob, using the
+// format already set on the wand (gif, apng, webp, ...). Also destroys the
+// current wand.
+//
+// Examples:
+//  seq := NewMageSequence()
+//  original, err := ioutil.ReadFile("test.gif")
+//  err = seq.ReadBlob(original)
+//  seq.ResizeAll(100, 100)
+//  imageBytes := seq.ExportBlob()
+func (s *MageSequence) ExportBlob() []byte {
+  defer s.Destroy()
+  newSize := C.ulong(0)
+  C.MagickResetIterator(s.wand)
+  images := C.MagickGetImagesBlob(s.wand, &newSize)
+  imagePointer := unsafe.Pointer(images)
+  defer C.MagickRelinquishMemory(imagePointer)
+  return C.GoBytes(imagePointer, C.int(newSize))
+}
+
+func (s *MageSequence) Destroy() {
+  defer C.DestroyMagickWand(s.wand)
+}
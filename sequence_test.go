@@ -0,0 +1,46 @@
+package mage
+
+import (
+  . "launchpad.net/gocheck"
+  "io/ioutil"
+  "os"
+  "image/gif"
+)
+
+func readSequence(c *C, filename string) *MageSequence {
+  var seq *MageSequence;
+  original, err := ioutil.ReadFile(filename)
+  c.Check(err, IsNil, Commentf("Fail to read image file"))
+
+  seq = NewMageSequence()
+  err = seq.ReadBlob(original)
+  c.Check(err, IsNil, Commentf("Fail to read blob"))
+  return seq
+}
+
+func writeSequence(seq *MageSequence, filename string) {
+  ioutil.WriteFile(filename, seq.ExportBlob(), 0644)
+}
+
+func (s *S) TestFrameCount(c *C) {
+  seq := readSequence(c, "images/in/test.gif")
+  c.Assert(seq.FrameCount() > 1, Equals, true)
+}
+
+func (s *S) TestResizeAll(c *C) {
+  filename := "images/out/test_resize_all.gif"
+  seq := readSequence(c, "images/in/test.gif")
+  err := seq.ResizeAll(100, 100)
+  c.Assert(err, IsNil)
+  writeSequence(seq, filename)
+
+  out, _ := os.Open(filename)
+  defer out.Close()
+  decoded, err := gif.DecodeAll(out)
+  c.Check(err, IsNil)
+  for _, frame := range decoded.Image {
+    bounds := frame.Bounds()
+    c.Assert(bounds.Dx(), Equals, 100)
+    c.Assert(bounds.Dy(), Equals, 100)
+  }
+}